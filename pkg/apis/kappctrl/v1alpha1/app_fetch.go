@@ -0,0 +1,114 @@
+// Copyright 2020 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+// AppFetch tells kapp-controller how to fetch the configuration that
+// will later be templated and deployed. Exactly one of the fields below
+// should be set.
+//
+// +k8s:deepcopy-gen=true
+type AppFetch struct {
+	// +optional
+	Image *AppFetchImage `json:"image,omitempty"`
+	// +optional
+	HTTP *AppFetchHTTP `json:"http,omitempty"`
+	// +optional
+	Git *AppFetchGit `json:"git,omitempty"`
+	// +optional
+	ImgpkgBundle *AppFetchImgpkgBundle `json:"imgpkgBundle,omitempty"`
+}
+
+// AppFetchLocalRef is a reference to a Secret in the App's own
+// namespace, e.g. one holding registry or basic-auth credentials.
+//
+// +k8s:deepcopy-gen=true
+type AppFetchLocalRef struct {
+	Name string `json:"name,omitempty"`
+}
+
+// AppFetchImage fetches the contents of a plain OCI image.
+//
+// +k8s:deepcopy-gen=true
+type AppFetchImage struct {
+	// +optional
+	URL string `json:"url,omitempty"`
+	// +optional
+	SecretRef *AppFetchLocalRef `json:"secretRef,omitempty"`
+
+	// CACertsFromClusterTrustBundle identifies one or more
+	// ClusterTrustBundles instead of a Secret cloned into this
+	// namespace.
+	//
+	// Note: this currently only drives ref-tracking so bundle rotation
+	// re-triggers a fetch; it is not yet wired into this fetch stage's
+	// TLS trust store, so it does not yet verify the registry's TLS
+	// certificate.
+	// +optional
+	CACertsFromClusterTrustBundle *AppFetchCACertsFromClusterTrustBundle `json:"caCertsFromClusterTrustBundle,omitempty"`
+}
+
+// AppFetchImgpkgBundle fetches an imgpkg bundle.
+//
+// +k8s:deepcopy-gen=true
+type AppFetchImgpkgBundle struct {
+	// +optional
+	Image string `json:"image,omitempty"`
+	// +optional
+	SecretRef *AppFetchLocalRef `json:"secretRef,omitempty"`
+
+	// CACertsFromClusterTrustBundle identifies one or more
+	// ClusterTrustBundles instead of a Secret cloned into this
+	// namespace.
+	//
+	// Note: this currently only drives ref-tracking so bundle rotation
+	// re-triggers a fetch; it is not yet wired into this fetch stage's
+	// TLS trust store, so it does not yet verify the registry's TLS
+	// certificate.
+	// +optional
+	CACertsFromClusterTrustBundle *AppFetchCACertsFromClusterTrustBundle `json:"caCertsFromClusterTrustBundle,omitempty"`
+}
+
+// AppFetchHTTP fetches a tarball over HTTP(S).
+//
+// +k8s:deepcopy-gen=true
+type AppFetchHTTP struct {
+	// +optional
+	URL string `json:"url,omitempty"`
+	// +optional
+	SecretRef *AppFetchLocalRef `json:"secretRef,omitempty"`
+
+	// CACertsFromClusterTrustBundle identifies one or more
+	// ClusterTrustBundles instead of a Secret cloned into this
+	// namespace.
+	//
+	// Note: this currently only drives ref-tracking so bundle rotation
+	// re-triggers a fetch; it is not yet wired into this fetch stage's
+	// TLS trust store, so it does not yet verify the server's TLS
+	// certificate.
+	// +optional
+	CACertsFromClusterTrustBundle *AppFetchCACertsFromClusterTrustBundle `json:"caCertsFromClusterTrustBundle,omitempty"`
+}
+
+// AppFetchGit fetches a git repository.
+//
+// +k8s:deepcopy-gen=true
+type AppFetchGit struct {
+	// +optional
+	URL string `json:"url,omitempty"`
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// +optional
+	SecretRef *AppFetchLocalRef `json:"secretRef,omitempty"`
+
+	// CACertsFromClusterTrustBundle identifies one or more
+	// ClusterTrustBundles instead of a Secret cloned into this
+	// namespace.
+	//
+	// Note: this currently only drives ref-tracking so bundle rotation
+	// re-triggers a fetch; it is not yet wired into this fetch stage's
+	// TLS trust store, so it does not yet verify the git server's TLS
+	// certificate.
+	// +optional
+	CACertsFromClusterTrustBundle *AppFetchCACertsFromClusterTrustBundle `json:"caCertsFromClusterTrustBundle,omitempty"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import "fmt"
+
+// AppFetchCACertsFromClusterTrustBundle identifies CA certificates for a
+// fetch stage (AppFetchImage, AppFetchImgpkgBundle, AppFetchHTTP,
+// AppFetchGit) to trust, sourced from one or more
+// certificates.k8s.io/v1alpha1 ClusterTrustBundles instead of a Secret
+// cloned into the App's namespace. Bundles may be selected by name or by
+// signer name combined with a label selector, matching the selection
+// rules of the ClusterTrustBundle API itself.
+//
+// Note: this currently only drives ref-tracking so bundle rotation
+// re-triggers a fetch (see reftracker.NewClusterTrustBundleKey /
+// NewClusterTrustBundleSelectorKey); no fetch stage yet turns a matched
+// bundle's Spec.TrustBundle into its TLS trust store.
+//
+// +k8s:deepcopy-gen=true
+type AppFetchCACertsFromClusterTrustBundle struct {
+	// Name of a single ClusterTrustBundle to trust. Mutually exclusive
+	// with SignerName/LabelSelector.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// SignerName restricts matching ClusterTrustBundles to ones issued
+	// for this signer. Used together with LabelSelector.
+	// +optional
+	SignerName string `json:"signerName,omitempty"`
+
+	// LabelSelector restricts matching ClusterTrustBundles by label. It
+	// may be set alone or together with SignerName.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// Validate reports an error if ref does not identify any
+// ClusterTrustBundle to select. Name, SignerName, and LabelSelector left
+// all empty is rejected here rather than treated as "match everything",
+// since that's what an empty SignerName/LabelSelector otherwise means to
+// the selector matching used for ref-tracking and, eventually, trust
+// data resolution.
+func (ref AppFetchCACertsFromClusterTrustBundle) Validate() error {
+	if ref.Name == "" && ref.SignerName == "" && ref.LabelSelector == "" {
+		return fmt.Errorf("Expected one of name, signerName or labelSelector to be set")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+// AppTemplateValuesFrom specifies a single source of template values.
+// Exactly one of the fields below should be set.
+//
+// +k8s:deepcopy-gen=true
+type AppTemplateValuesFrom struct {
+	// +optional
+	SecretRef *AppFetchLocalRef `json:"secretRef,omitempty"`
+	// +optional
+	ConfigMapRef *AppFetchLocalRef `json:"configMapRef,omitempty"`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ResourceRef names an arbitrary cluster resource (e.g. a user CRD
+	// such as a Cluster or Config object) that this App depends on. The
+	// referenced resource's GVK must have been registered with
+	// AppsReconciler.RegisterRefSource for changes to it to trigger
+	// re-reconciliation of this App. It is only used for ref-tracking;
+	// no template stage reads values from the referenced resource.
+	// +optional
+	ResourceRef *AppTemplateValuesFromResourceRef `json:"resourceRef,omitempty"`
+}
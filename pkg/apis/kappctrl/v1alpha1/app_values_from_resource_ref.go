@@ -0,0 +1,41 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+// AppTemplateValuesFromResourceRef is the resourceRef field of
+// AppTemplateValuesFrom. It names an arbitrary cluster resource (e.g. a
+// user CRD such as a Cluster or Config object) that this App depends
+// on. The referenced resource's GVK must have been registered with
+// AppsReconciler.RegisterRefSource for changes to it to trigger
+// re-reconciliation of this App.
+//
+// Note: the field is only read to build ref-tracking keys (see
+// resourceRefKeys in pkg/app); no template stage reads values from the
+// referenced resource, and JSONPath is not evaluated.
+//
+// +k8s:deepcopy-gen=true
+type AppTemplateValuesFromResourceRef struct {
+	// APIVersion of the referenced resource, e.g. "example.com/v1".
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the referenced resource, e.g. "Cluster".
+	Kind string `json:"kind"`
+
+	// Name of the referenced resource, tracked in the App's own
+	// namespace.
+	//
+	// Note: ref-tracking for re-reconciliation currently assumes the
+	// referenced GVK is namespace-scoped (see resourceRefKeys in
+	// pkg/app). A change to a cluster-scoped CR will not re-trigger
+	// reconciliation: the RefKey built for it will carry the App's
+	// namespace, which will never match the no-namespace key a
+	// RegisterRefSource keyExtractor builds from the actual
+	// cluster-scoped object.
+	Name string `json:"name"`
+
+	// JSONPath names the portion of the referenced resource holding
+	// template values, e.g. "{.spec.config}". It is stored as given and
+	// not evaluated.
+	JSONPath string `json:"jsonPath"`
+}
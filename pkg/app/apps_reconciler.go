@@ -13,9 +13,13 @@ import (
 	kcclient "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned"
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reconciler"
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/sharding"
+	certificatesv1alpha1 "k8s.io/api/certificates/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -24,17 +28,37 @@ import (
 
 // AppsReconciler is responsible for reconciling Apps.
 type AppsReconciler struct {
-	appClient       kcclient.Interface
-	log             logr.Logger
-	appFactory      AppFactory
-	appRefTracker   *reftracker.AppRefTracker
-	appUpdateStatus *reftracker.AppUpdateStatus
+	appClient        kcclient.Interface
+	log              logr.Logger
+	appFactory       AppFactory
+	appRefTracker    *reftracker.AppRefTracker
+	appUpdateStatus  *reftracker.AppUpdateStatus
+	shardIdentity    string
+	shardResolver    sharding.ShardResolver
+	dynamicClient    dynamic.Interface
+	refSources       []refSource
+	refSourceFactory dynamicinformer.DynamicSharedInformerFactory
 }
 
 // NewAppsReconciler constructs new AppsReconciler.
 func NewAppsReconciler(appClient kcclient.Interface, log logr.Logger, appFactory AppFactory,
 	appRefTracker *reftracker.AppRefTracker, appUpdateStatus *reftracker.AppUpdateStatus) *AppsReconciler {
-	return &AppsReconciler{appClient, log, appFactory, appRefTracker, appUpdateStatus}
+	return &AppsReconciler{appClient: appClient, log: log, appFactory: appFactory,
+		appRefTracker: appRefTracker, appUpdateStatus: appUpdateStatus}
+}
+
+// WithSharding enables lease-based sharding of App reconciliation: Apps
+// that do not belong to shardIdentity according to resolver are skipped
+// by Reconcile instead of being reconciled by every replica. Called
+// after construction so that single-replica installs (the common case)
+// can leave sharding disabled entirely by never calling it. The caller
+// is also responsible for registering a sharding.LeasePublisher for
+// shardIdentity with the manager (mgr.Add), since resolver only
+// consumes Leases, it does not publish this replica's own.
+func (r *AppsReconciler) WithSharding(shardIdentity string, resolver sharding.ShardResolver) *AppsReconciler {
+	r.shardIdentity = shardIdentity
+	r.shardResolver = resolver
+	return r
 }
 
 var _ reconcile.Reconciler = &AppsReconciler{}
@@ -59,12 +83,30 @@ func (r *AppsReconciler) AttachWatches(controller controller.Controller) error {
 		return fmt.Errorf("Watch ConfigMaps: %s", err)
 	}
 
-	return nil
+	ctbHandler := reconciler.NewClusterTrustBundleHandler(r.log, r.appRefTracker, r.appUpdateStatus)
+
+	err = controller.Watch(&source.Kind{Type: &certificatesv1alpha1.ClusterTrustBundle{}}, ctbHandler)
+	if err != nil {
+		return fmt.Errorf("Watch ClusterTrustBundles: %s", err)
+	}
+
+	return r.attachRefSourceWatches(controller)
 }
 
 func (r *AppsReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := r.log.WithValues("request", request)
 
+	if r.shardResolver != nil {
+		managed, err := r.shardResolver.IsManagedByShard(ctx, request.Namespace, request.Name, r.shardIdentity)
+		if err != nil {
+			log.Error(err, "Could not resolve shard for App")
+			return reconcile.Result{}, err
+		}
+		if !managed {
+			return reconcile.Result{}, nil // Another shard owns this App
+		}
+	}
+
 	// TODO currently we've decided to get a fresh copy of app so
 	// that we do not operate on stale copy for efficiency reasons
 	existingApp, err := r.appClient.KappctrlV1alpha1().Apps(request.Namespace).Get(ctx, request.Name, metav1.GetOptions{})
@@ -79,7 +121,18 @@ func (r *AppsReconciler) Reconcile(ctx context.Context, request reconcile.Reques
 	}
 
 	crdApp := r.appFactory.NewCRDApp(existingApp, log)
-	r.UpdateAppRefs(crdApp.ResourceRefs(), existingApp)
+	refKeys := map[reftracker.RefKey]struct{}{}
+	mergeRefKeys(refKeys, crdApp.ResourceRefs())
+
+	ctbRefKeys, err := clusterTrustBundleRefKeys(existingApp)
+	if err != nil {
+		log.Error(err, "Could not determine ClusterTrustBundle refs for App")
+		return reconcile.Result{}, err
+	}
+	mergeRefKeys(refKeys, ctbRefKeys)
+
+	mergeRefKeys(refKeys, resourceRefKeys(existingApp))
+	r.UpdateAppRefs(refKeys, existingApp)
 
 	force := false
 	appKey := reftracker.NewAppKey(existingApp.Name, existingApp.Namespace)
@@ -108,3 +161,10 @@ func (r *AppsReconciler) UpdateAppRefs(refKeys map[reftracker.RefKey]struct{}, a
 func (r *AppsReconciler) AppRefTracker() *reftracker.AppRefTracker {
 	return r.appRefTracker
 }
+
+// mergeRefKeys adds every key in from to into.
+func mergeRefKeys(into, from map[reftracker.RefKey]struct{}) {
+	for refKey := range from {
+		into[refKey] = struct{}{}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+)
+
+// clusterTrustBundleRefKeys returns the RefKeys for every
+// CACertsFromClusterTrustBundle set across app's fetch stages, so that
+// AppsReconciler.Reconcile can merge them into the refs tracked for app
+// alongside whatever crdApp.ResourceRefs() already returns for its
+// Secret/ConfigMap refs. Without this, bundle rotation would never
+// re-trigger a fetch for any real App: AppRefTracker would simply have
+// no entry to match against. Returns an error if any fetch stage sets a
+// CACertsFromClusterTrustBundle that fails Validate.
+func clusterTrustBundleRefKeys(app *kcv1alpha1.App) (map[reftracker.RefKey]struct{}, error) {
+	refKeys := map[reftracker.RefKey]struct{}{}
+
+	for _, fetch := range app.Spec.Fetch {
+		ref := fetchCACertsFromClusterTrustBundle(fetch)
+		if ref == nil {
+			continue
+		}
+		if err := ref.Validate(); err != nil {
+			return nil, fmt.Errorf("Validating caCertsFromClusterTrustBundle: %s", err)
+		}
+		addClusterTrustBundleRefKey(refKeys, ref)
+	}
+
+	return refKeys, nil
+}
+
+// fetchCACertsFromClusterTrustBundle returns the
+// CACertsFromClusterTrustBundle set on whichever of fetch's mutually
+// exclusive stages is populated, or nil if none is.
+func fetchCACertsFromClusterTrustBundle(fetch kcv1alpha1.AppFetch) *kcv1alpha1.AppFetchCACertsFromClusterTrustBundle {
+	switch {
+	case fetch.Image != nil:
+		return fetch.Image.CACertsFromClusterTrustBundle
+	case fetch.HTTP != nil:
+		return fetch.HTTP.CACertsFromClusterTrustBundle
+	case fetch.Git != nil:
+		return fetch.Git.CACertsFromClusterTrustBundle
+	case fetch.ImgpkgBundle != nil:
+		return fetch.ImgpkgBundle.CACertsFromClusterTrustBundle
+	default:
+		return nil
+	}
+}
+
+func addClusterTrustBundleRefKey(refKeys map[reftracker.RefKey]struct{}, ref *kcv1alpha1.AppFetchCACertsFromClusterTrustBundle) {
+	if ref == nil {
+		return
+	}
+
+	if ref.Name != "" {
+		refKeys[reftracker.NewClusterTrustBundleKey(ref.Name)] = struct{}{}
+		return
+	}
+
+	refKeys[reftracker.NewClusterTrustBundleSelectorKey(ref.SignerName, ref.LabelSelector)] = struct{}{}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+)
+
+func TestClusterTrustBundleRefKeysByName(t *testing.T) {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Fetch: []kcv1alpha1.AppFetch{{
+				Image: &kcv1alpha1.AppFetchImage{
+					URL: "registry.example.com/foo",
+					CACertsFromClusterTrustBundle: &kcv1alpha1.AppFetchCACertsFromClusterTrustBundle{
+						Name: "my-bundle",
+					},
+				},
+			}},
+		},
+	}
+
+	refKeys, err := clusterTrustBundleRefKeys(app)
+	if err != nil {
+		t.Fatalf("clusterTrustBundleRefKeys: %s", err)
+	}
+
+	want := reftracker.NewClusterTrustBundleKey("my-bundle")
+	if _, found := refKeys[want]; !found || len(refKeys) != 1 {
+		t.Fatalf("expected only %v, got %v", want, refKeys)
+	}
+}
+
+func TestClusterTrustBundleRefKeysBySelector(t *testing.T) {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Fetch: []kcv1alpha1.AppFetch{{
+				HTTP: &kcv1alpha1.AppFetchHTTP{
+					URL: "https://example.com/foo.tar",
+					CACertsFromClusterTrustBundle: &kcv1alpha1.AppFetchCACertsFromClusterTrustBundle{
+						SignerName:    "example.com/signer",
+						LabelSelector: "env=prod",
+					},
+				},
+			}},
+		},
+	}
+
+	refKeys, err := clusterTrustBundleRefKeys(app)
+	if err != nil {
+		t.Fatalf("clusterTrustBundleRefKeys: %s", err)
+	}
+
+	want := reftracker.NewClusterTrustBundleSelectorKey("example.com/signer", "env=prod")
+	if _, found := refKeys[want]; !found || len(refKeys) != 1 {
+		t.Fatalf("expected only %v, got %v", want, refKeys)
+	}
+}
+
+func TestClusterTrustBundleRefKeysEmptyWithoutCACerts(t *testing.T) {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Fetch: []kcv1alpha1.AppFetch{{
+				Git: &kcv1alpha1.AppFetchGit{URL: "https://example.com/repo.git"},
+			}},
+		},
+	}
+
+	refKeys, err := clusterTrustBundleRefKeys(app)
+	if err != nil {
+		t.Fatalf("clusterTrustBundleRefKeys: %s", err)
+	}
+	if len(refKeys) != 0 {
+		t.Fatalf("expected no ref keys, got %v", refKeys)
+	}
+}
+
+func TestClusterTrustBundleRefKeysRejectsFullyEmptyRef(t *testing.T) {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Fetch: []kcv1alpha1.AppFetch{{
+				Image: &kcv1alpha1.AppFetchImage{
+					URL:                           "registry.example.com/foo",
+					CACertsFromClusterTrustBundle: &kcv1alpha1.AppFetchCACertsFromClusterTrustBundle{},
+				},
+			}},
+		},
+	}
+
+	if _, err := clusterTrustBundleRefKeys(app); err == nil {
+		t.Fatal("expected an error for a caCertsFromClusterTrustBundle with name, signerName and labelSelector all empty, got none")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var _ manager.Runnable = &AppsReconciler{}
+
+// refSource is a registered (GVK, keyExtractor) pair that
+// attachRefSourceWatches turns into a dynamic informer and a
+// reconciler.resourceHandler, the same way the built-in
+// Secret/ConfigMap/ClusterTrustBundle watches are wired up.
+type refSource struct {
+	gvk          schema.GroupVersionKind
+	keyExtractor reconciler.RefKeyExtractor
+}
+
+// RegisterRefSource registers a GVK that Apps may reference (e.g. via
+// valuesFrom.resourceRef) so that a change to a matching resource
+// re-reconciles the Apps that depend on it. keyExtractor derives the
+// RefKey a changed resource is tracked under; callers typically build it
+// with reftracker.NewResourceKey(gvk, obj.GetNamespace(), obj.GetName()).
+// Must be called before AttachWatches, and requires WithDynamicClient to
+// have been called first.
+func (r *AppsReconciler) RegisterRefSource(gvk schema.GroupVersionKind, keyExtractor reconciler.RefKeyExtractor) {
+	r.refSources = append(r.refSources, refSource{gvk: gvk, keyExtractor: keyExtractor})
+}
+
+// WithDynamicClient supplies the dynamic client used to build informers
+// for GVKs registered via RegisterRefSource.
+func (r *AppsReconciler) WithDynamicClient(dynamicClient dynamic.Interface) *AppsReconciler {
+	r.dynamicClient = dynamicClient
+	return r
+}
+
+// attachRefSourceWatches builds a filtered dynamic informer for each GVK
+// registered via RegisterRefSource and wires it to a
+// reconciler.resourceHandler. The informers are not started here: they
+// only begin delivering events once Start runs, so callers must also
+// register the AppsReconciler with the manager (mgr.Add(appsReconciler))
+// after calling AttachWatches.
+func (r *AppsReconciler) attachRefSourceWatches(ctl controller.Controller) error {
+	if len(r.refSources) == 0 {
+		return nil
+	}
+	if r.dynamicClient == nil {
+		return fmt.Errorf("Watch CRD ref sources: dynamic client not configured (call WithDynamicClient)")
+	}
+
+	r.refSourceFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(r.dynamicClient, 0, metav1.NamespaceAll, nil)
+
+	for _, rs := range r.refSources {
+		// Simplistic GVK->GVR mapping: assumes the conventional plural
+		// lower-cased resource name. Ref sources whose resource name
+		// doesn't follow that convention should be registered with an
+		// explicit GVR once this API grows that option.
+		gvr, _ := meta.UnsafeGuessKindToResource(rs.gvk)
+
+		refHandler := reconciler.NewResourceHandler(r.log, r.appRefTracker, r.appUpdateStatus, rs.keyExtractor)
+
+		err := ctl.Watch(&source.Informer{Informer: r.refSourceFactory.ForResource(gvr).Informer()}, refHandler)
+		if err != nil {
+			return fmt.Errorf("Watch %s: %s", rs.gvk, err)
+		}
+	}
+
+	return nil
+}
+
+// Start implements manager.Runnable. It starts the dynamic informer
+// factory backing any GVKs registered via RegisterRefSource, so their
+// informers actually sync and deliver events; without this, the
+// informers built by attachRefSourceWatches are wired to handlers but
+// never run. No-op when no ref sources were registered.
+func (r *AppsReconciler) Start(ctx context.Context) error {
+	if r.refSourceFactory == nil {
+		return nil
+	}
+
+	r.refSourceFactory.Start(ctx.Done())
+	<-ctx.Done()
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourceRefKeys returns the RefKeys for every valuesFrom.resourceRef
+// set across app's template values sources, so that
+// AppsReconciler.Reconcile can merge them into the refs tracked for app
+// alongside whatever crdApp.ResourceRefs() already returns. Without
+// this, an App templating values from a registered CRD would never
+// re-reconcile when that CRD instance changed: AppRefTracker would have
+// no entry for it to match against, no matter how many GVKs were
+// registered via RegisterRefSource.
+//
+// Limitation: this always builds the RefKey with app.Namespace,
+// regardless of whether ref.Kind is actually namespace-scoped. For a
+// cluster-scoped referenced resource, a RegisterRefSource keyExtractor
+// builds its RefKey with an empty namespace (matching
+// obj.GetNamespace()), so the keys built here will never match and
+// changes to that resource will not re-trigger reconciliation. There is
+// no RESTMapper available here to detect scope; resourceRefKeys assumes
+// namespace-scoped until one is plumbed through.
+//
+// TODO plumb a RESTMapper into AppsReconciler so resourceRefKeys can
+// build a no-namespace key for cluster-scoped GVKs instead of permanently
+// assuming namespace-scoped; cluster-scoped CRs (e.g. a Cluster object)
+// are a common case this currently can't cover.
+func resourceRefKeys(app *kcv1alpha1.App) map[reftracker.RefKey]struct{} {
+	refKeys := map[reftracker.RefKey]struct{}{}
+
+	for _, valuesFrom := range app.Spec.Template.ValuesFrom {
+		ref := valuesFrom.ResourceRef
+		if ref == nil {
+			continue
+		}
+
+		gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+		refKeys[reftracker.NewResourceKey(gvk, app.Namespace, ref.Name)] = struct{}{}
+	}
+
+	return refKeys
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourceRefKeys(t *testing.T) {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Template: kcv1alpha1.AppTemplate{
+				ValuesFrom: []kcv1alpha1.AppTemplateValuesFrom{{
+					ResourceRef: &kcv1alpha1.AppTemplateValuesFromResourceRef{
+						APIVersion: "example.com/v1",
+						Kind:       "Cluster",
+						Name:       "my-cluster",
+						JSONPath:   "{.spec.config}",
+					},
+				}},
+			},
+		},
+	}
+	app.Namespace = "ns1"
+
+	refKeys := resourceRefKeys(app)
+
+	want := reftracker.NewResourceKey(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Cluster"}, "ns1", "my-cluster")
+	if _, found := refKeys[want]; !found || len(refKeys) != 1 {
+		t.Fatalf("expected only %v, got %v", want, refKeys)
+	}
+}
+
+func TestResourceRefKeysEmptyWithoutResourceRef(t *testing.T) {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Template: kcv1alpha1.AppTemplate{
+				ValuesFrom: []kcv1alpha1.AppTemplateValuesFrom{{
+					SecretRef: &kcv1alpha1.AppFetchLocalRef{Name: "my-secret"},
+				}},
+			},
+		},
+	}
+
+	if refKeys := resourceRefKeys(app); len(refKeys) != 0 {
+		t.Fatalf("expected no ref keys, got %v", refKeys)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	certificatesv1alpha1 "k8s.io/api/certificates/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// clusterTrustBundleHandler enqueues Apps that reference a
+// ClusterTrustBundle whenever that bundle is created, updated, or
+// deleted. It mirrors secretHandler/configMapHandler but keys off of
+// ClusterTrustBundle name since bundles are cluster-scoped, and
+// additionally matches bundles selected by SignerName/LabelSelector
+// (see reftracker.NewClusterTrustBundleSelectorKey) against each changed
+// bundle directly, since such a selector has no single bundle name to
+// key off of.
+type clusterTrustBundleHandler struct {
+	log             logr.Logger
+	appRefTracker   *reftracker.AppRefTracker
+	appUpdateStatus *reftracker.AppUpdateStatus
+}
+
+var _ handler.EventHandler = &clusterTrustBundleHandler{}
+
+// NewClusterTrustBundleHandler constructs a handler that enqueues Apps
+// referencing a changed ClusterTrustBundle for reconciliation.
+func NewClusterTrustBundleHandler(log logr.Logger, appRefTracker *reftracker.AppRefTracker, appUpdateStatus *reftracker.AppUpdateStatus) handler.EventHandler {
+	return &clusterTrustBundleHandler{log, appRefTracker, appUpdateStatus}
+}
+
+func (h *clusterTrustBundleHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.Object, q)
+}
+
+func (h *clusterTrustBundleHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.ObjectNew, q)
+}
+
+func (h *clusterTrustBundleHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.Object, q)
+}
+
+func (h *clusterTrustBundleHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.Object, q)
+}
+
+func (h *clusterTrustBundleHandler) enqueue(obj client.Object, q workqueue.RateLimitingInterface) {
+	ctb, ok := obj.(*certificatesv1alpha1.ClusterTrustBundle)
+	if !ok {
+		return
+	}
+
+	appKeys := map[reftracker.AppKey]struct{}{}
+
+	for _, appKey := range h.appRefTracker.AppsForRef(reftracker.NewClusterTrustBundleKey(ctb.Name)) {
+		appKeys[appKey] = struct{}{}
+	}
+
+	// A bundle selected by SignerName/LabelSelector (rather than by
+	// literal Name) isn't keyed under ctb.Name, so every tracked
+	// selector has to be checked against ctb itself to see if it now
+	// matches.
+	for _, selectorKey := range h.appRefTracker.ClusterTrustBundleSelectorRefs() {
+		if !clusterTrustBundleMatchesSelector(selectorKey.SignerName, selectorKey.LabelSelector, ctb) {
+			continue
+		}
+		for _, appKey := range h.appRefTracker.AppsForRef(selectorKey) {
+			appKeys[appKey] = struct{}{}
+		}
+	}
+
+	for appKey := range appKeys {
+		h.appUpdateStatus.MarkForUpdate(appKey)
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      appKey.Name,
+			Namespace: appKey.Namespace,
+		}})
+		h.log.Info("Enqueuing App due to ClusterTrustBundle change", "app", appKey.Name, "namespace", appKey.Namespace, "clusterTrustBundle", ctb.Name)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	certificatesv1alpha1 "k8s.io/api/certificates/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestClusterTrustBundleHandlerEnqueuesReferencingApps(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("app1", "ns1")
+	refKey := reftracker.NewClusterTrustBundleKey("my-bundle")
+	appRefTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{refKey: {}}, appKey)
+
+	handler := NewClusterTrustBundleHandler(logr.Discard(), appRefTracker, appUpdateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	ctb := &certificatesv1alpha1.ClusterTrustBundle{ObjectMeta: metav1.ObjectMeta{Name: "my-bundle"}}
+	handler.Create(event.CreateEvent{Object: ctb}, queue)
+
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 item queued, got %d", queue.Len())
+	}
+
+	item, _ := queue.Get()
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		t.Fatalf("expected reconcile.Request, got %T", item)
+	}
+	if req.NamespacedName != (types.NamespacedName{Name: "app1", Namespace: "ns1"}) {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	if !appUpdateStatus.IsUpdateNeeded(appKey) {
+		t.Fatalf("expected appUpdateStatus to mark app1 for a forced update")
+	}
+}
+
+func TestClusterTrustBundleHandlerIgnoresUnrelatedBundle(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("app1", "ns1")
+	refKey := reftracker.NewClusterTrustBundleKey("my-bundle")
+	appRefTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{refKey: {}}, appKey)
+
+	handler := NewClusterTrustBundleHandler(logr.Discard(), appRefTracker, appUpdateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	ctb := &certificatesv1alpha1.ClusterTrustBundle{ObjectMeta: metav1.ObjectMeta{Name: "other-bundle"}}
+	handler.Create(event.CreateEvent{Object: ctb}, queue)
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected no items queued for an unrelated bundle, got %d", queue.Len())
+	}
+	if appUpdateStatus.IsUpdateNeeded(appKey) {
+		t.Fatalf("expected app1 to not be marked for a forced update")
+	}
+}
+
+func TestClusterTrustBundleHandlerEnqueuesAppsMatchingSelector(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("app1", "ns1")
+	refKey := reftracker.NewClusterTrustBundleSelectorKey("example.com/signer", "env=prod")
+	appRefTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{refKey: {}}, appKey)
+
+	handler := NewClusterTrustBundleHandler(logr.Discard(), appRefTracker, appUpdateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	ctb := &certificatesv1alpha1.ClusterTrustBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "rotated-bundle", Labels: map[string]string{"env": "prod"}},
+		Spec:       certificatesv1alpha1.ClusterTrustBundleSpec{SignerName: "example.com/signer"},
+	}
+	handler.Create(event.CreateEvent{Object: ctb}, queue)
+
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 item queued for a bundle matching the tracked selector, got %d", queue.Len())
+	}
+	if !appUpdateStatus.IsUpdateNeeded(appKey) {
+		t.Fatalf("expected appUpdateStatus to mark app1 for a forced update")
+	}
+}
+
+func TestClusterTrustBundleHandlerIgnoresBundleNotMatchingSelector(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("app1", "ns1")
+	refKey := reftracker.NewClusterTrustBundleSelectorKey("example.com/signer", "env=prod")
+	appRefTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{refKey: {}}, appKey)
+
+	handler := NewClusterTrustBundleHandler(logr.Discard(), appRefTracker, appUpdateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	ctb := &certificatesv1alpha1.ClusterTrustBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-bundle", Labels: map[string]string{"env": "staging"}},
+		Spec:       certificatesv1alpha1.ClusterTrustBundleSpec{SignerName: "example.com/signer"},
+	}
+	handler.Create(event.CreateEvent{Object: ctb}, queue)
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected no items queued for a bundle not matching the tracked selector, got %d", queue.Len())
+	}
+}
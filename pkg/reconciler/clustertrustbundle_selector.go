@@ -0,0 +1,35 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	certificatesv1alpha1 "k8s.io/api/certificates/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func parseClusterTrustBundleSelector(labelSelector string) (labels.Selector, error) {
+	if labelSelector == "" {
+		return labels.Everything(), nil
+	}
+	return labels.Parse(labelSelector)
+}
+
+// clusterTrustBundleMatchesSelector reports whether bundle matches the
+// selector described by signerName/labelSelector, with the same
+// semantics as NewClusterTrustBundleSelectorKey: an empty signerName
+// matches any signer, and an empty labelSelector matches any labels.
+func clusterTrustBundleMatchesSelector(signerName, labelSelector string, bundle *certificatesv1alpha1.ClusterTrustBundle) bool {
+	if !clusterTrustBundleMatches(signerName, bundle) {
+		return false
+	}
+	selector, err := parseClusterTrustBundleSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(bundle.Labels))
+}
+
+func clusterTrustBundleMatches(signerName string, bundle *certificatesv1alpha1.ClusterTrustBundle) bool {
+	return signerName == "" || bundle.Spec.SignerName == signerName
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RefKeyExtractor derives the RefKey that a changed resource is tracked
+// under from its unstructured representation, e.g. by its name or by a
+// label selector result. A false second return skips the event.
+type RefKeyExtractor func(obj *unstructured.Unstructured) (reftracker.RefKey, bool)
+
+// resourceHandler enqueues Apps that reference a changed resource of an
+// arbitrary, caller-registered GVK. It generalizes
+// secretHandler/configMapHandler/clusterTrustBundleHandler to any kind
+// registered through AppsReconciler.RegisterRefSource.
+type resourceHandler struct {
+	log             logr.Logger
+	appRefTracker   *reftracker.AppRefTracker
+	appUpdateStatus *reftracker.AppUpdateStatus
+	keyExtractor    RefKeyExtractor
+}
+
+var _ handler.EventHandler = &resourceHandler{}
+
+// NewResourceHandler constructs a handler that enqueues Apps
+// referencing a changed resource, as identified by keyExtractor.
+func NewResourceHandler(log logr.Logger, appRefTracker *reftracker.AppRefTracker, appUpdateStatus *reftracker.AppUpdateStatus, keyExtractor RefKeyExtractor) handler.EventHandler {
+	return &resourceHandler{log, appRefTracker, appUpdateStatus, keyExtractor}
+}
+
+func (h *resourceHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.Object, q)
+}
+
+func (h *resourceHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.ObjectNew, q)
+}
+
+func (h *resourceHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.Object, q)
+}
+
+func (h *resourceHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(e.Object, q)
+}
+
+func (h *resourceHandler) enqueue(obj client.Object, q workqueue.RateLimitingInterface) {
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	refKey, ok := h.keyExtractor(unstructuredObj)
+	if !ok {
+		return
+	}
+
+	for _, appKey := range h.appRefTracker.AppsForRef(refKey) {
+		h.appUpdateStatus.MarkForUpdate(appKey)
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      appKey.Name,
+			Namespace: appKey.Namespace,
+		}})
+		h.log.Info("Enqueuing App due to resource change", "app", appKey.Name, "namespace", appKey.Namespace,
+			"gvk", refKey.GVK, "resource", refKey.Name)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func clusterGVKForTest() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Cluster"}
+}
+
+func resourceKeyExtractorForTest() RefKeyExtractor {
+	gvk := clusterGVKForTest()
+	return func(obj *unstructured.Unstructured) (reftracker.RefKey, bool) {
+		return reftracker.NewResourceKey(gvk, obj.GetNamespace(), obj.GetName()), true
+	}
+}
+
+func TestResourceHandlerEnqueuesReferencingApps(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("app1", "ns1")
+	refKey := reftracker.NewResourceKey(clusterGVKForTest(), "ns1", "my-cluster")
+	appRefTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{refKey: {}}, appKey)
+
+	handler := NewResourceHandler(logr.Discard(), appRefTracker, appUpdateStatus, resourceKeyExtractorForTest())
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace("ns1")
+	obj.SetName("my-cluster")
+	handler.Create(event.CreateEvent{Object: obj}, queue)
+
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 item queued, got %d", queue.Len())
+	}
+
+	item, _ := queue.Get()
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		t.Fatalf("expected reconcile.Request, got %T", item)
+	}
+	if req.NamespacedName != (types.NamespacedName{Name: "app1", Namespace: "ns1"}) {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	if !appUpdateStatus.IsUpdateNeeded(appKey) {
+		t.Fatalf("expected appUpdateStatus to mark app1 for a forced update")
+	}
+}
+
+func TestResourceHandlerIgnoresUnrelatedResource(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("app1", "ns1")
+	refKey := reftracker.NewResourceKey(clusterGVKForTest(), "ns1", "my-cluster")
+	appRefTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{refKey: {}}, appKey)
+
+	handler := NewResourceHandler(logr.Discard(), appRefTracker, appUpdateStatus, resourceKeyExtractorForTest())
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace("ns1")
+	obj.SetName("other-cluster")
+	handler.Create(event.CreateEvent{Object: obj}, queue)
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected no items queued for an unrelated resource, got %d", queue.Len())
+	}
+}
+
+func TestResourceHandlerSkipsWhenKeyExtractorDeclines(t *testing.T) {
+	appRefTracker := reftracker.NewAppRefTracker()
+	appUpdateStatus := reftracker.NewAppUpdateStatus()
+
+	handler := NewResourceHandler(logr.Discard(), appRefTracker, appUpdateStatus, func(*unstructured.Unstructured) (reftracker.RefKey, bool) {
+		return reftracker.RefKey{}, false
+	})
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	handler.Create(event.CreateEvent{Object: &unstructured.Unstructured{}}, queue)
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected no items queued when keyExtractor declines, got %d", queue.Len())
+	}
+}
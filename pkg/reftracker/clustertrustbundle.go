@@ -0,0 +1,27 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reftracker
+
+// ClusterTrustBundleKind identifies refs pointing at a cluster-scoped
+// certificates.k8s.io ClusterTrustBundle.
+const ClusterTrustBundleKind RefKind = "ClusterTrustBundle"
+
+// NewClusterTrustBundleKey builds a RefKey for a ClusterTrustBundle
+// referenced by name. Unlike NewSecretKey/NewConfigMapKey, a
+// ClusterTrustBundle is cluster-scoped so its key carries no namespace.
+func NewClusterTrustBundleKey(name string) RefKey {
+	return RefKey{Kind: ClusterTrustBundleKind, Name: name}
+}
+
+// NewClusterTrustBundleSelectorKey builds a RefKey for the set of
+// ClusterTrustBundles matching signerName and labelSelector, for the
+// AppFetchCACertsFromClusterTrustBundle case where bundles are selected
+// by signer/label rather than by a single literal name. Whether a given
+// bundle currently matches is resolved by the reconciler package against
+// the changed bundle directly; this RefKey only identifies the selector
+// itself so that a change to a matching bundle can be mapped back to the
+// Apps that used it.
+func NewClusterTrustBundleSelectorKey(signerName, labelSelector string) RefKey {
+	return RefKey{Kind: ClusterTrustBundleKind, SignerName: signerName, LabelSelector: labelSelector}
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reftracker keeps track of which Apps reference which
+// external resources (Secrets, ConfigMaps, ClusterTrustBundles, ...)
+// so that a change to one of those resources can be mapped back to the
+// Apps that need to be re-reconciled.
+package reftracker
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RefKind identifies what kind of resource a RefKey refers to.
+type RefKind string
+
+const (
+	SecretKind    RefKind = "Secret"
+	ConfigMapKind RefKind = "ConfigMap"
+	// ResourceKind marks a RefKey built from an arbitrary registered
+	// GVK (see NewResourceKey) rather than one of the built-in kinds
+	// above. GVK disambiguates which such resource is meant.
+	ResourceKind RefKind = "Resource"
+)
+
+// RefKey uniquely identifies a resource that one or more Apps
+// reference. Namespace is empty for cluster-scoped resources such as
+// ClusterTrustBundles. GVK is only populated for RefKeys of
+// ResourceKind; the built-in kinds are implicitly typed by Kind alone.
+// SignerName/LabelSelector are only populated for ClusterTrustBundleKind
+// RefKeys built by NewClusterTrustBundleSelectorKey, where Name is left
+// empty because the bundle is selected by signer/label rather than by a
+// literal name (see NewClusterTrustBundleKey for that case).
+type RefKey struct {
+	Kind          RefKind
+	GVK           schema.GroupVersionKind
+	Namespace     string
+	Name          string
+	SignerName    string
+	LabelSelector string
+}
+
+// NewSecretKey builds a RefKey for a namespaced Secret.
+func NewSecretKey(name, namespace string) RefKey {
+	return RefKey{Kind: SecretKind, Namespace: namespace, Name: name}
+}
+
+// NewConfigMapKey builds a RefKey for a namespaced ConfigMap.
+func NewConfigMapKey(name, namespace string) RefKey {
+	return RefKey{Kind: ConfigMapKind, Namespace: namespace, Name: name}
+}
+
+// AppKey uniquely identifies an App.
+type AppKey struct {
+	Name      string
+	Namespace string
+}
+
+// NewAppKey builds an AppKey for an App.
+func NewAppKey(name, namespace string) AppKey {
+	return AppKey{Name: name, Namespace: namespace}
+}
+
+// AppRefTracker tracks the associations between Apps and the external
+// resources they reference, so that a change to a referenced resource
+// can be mapped back to the Apps that need to be re-reconciled.
+type AppRefTracker struct {
+	lock      sync.Mutex
+	refToApps map[RefKey]map[AppKey]struct{}
+	appToRefs map[AppKey]map[RefKey]struct{}
+}
+
+// NewAppRefTracker constructs an empty AppRefTracker.
+func NewAppRefTracker() *AppRefTracker {
+	return &AppRefTracker{
+		refToApps: map[RefKey]map[AppKey]struct{}{},
+		appToRefs: map[AppKey]map[RefKey]struct{}{},
+	}
+}
+
+// ReconcileRefs updates the tracked refs for appKey to exactly refKeys,
+// adding new associations and removing ones that are no longer present.
+func (t *AppRefTracker) ReconcileRefs(refKeys map[RefKey]struct{}, appKey AppKey) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for existingRef := range t.appToRefs[appKey] {
+		if _, found := refKeys[existingRef]; !found {
+			t.removeAppFromRefLocked(existingRef, appKey)
+		}
+	}
+
+	for refKey := range refKeys {
+		if t.refToApps[refKey] == nil {
+			t.refToApps[refKey] = map[AppKey]struct{}{}
+		}
+		t.refToApps[refKey][appKey] = struct{}{}
+	}
+	t.appToRefs[appKey] = refKeys
+}
+
+// RemoveAppFromAllRefs removes all ref associations for appKey, e.g.
+// when the App has been deleted.
+func (t *AppRefTracker) RemoveAppFromAllRefs(appKey AppKey) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for refKey := range t.appToRefs[appKey] {
+		t.removeAppFromRefLocked(refKey, appKey)
+	}
+	delete(t.appToRefs, appKey)
+}
+
+// AppsForRef returns the Apps currently associated with refKey.
+func (t *AppRefTracker) AppsForRef(refKey RefKey) []AppKey {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	apps := make([]AppKey, 0, len(t.refToApps[refKey]))
+	for appKey := range t.refToApps[refKey] {
+		apps = append(apps, appKey)
+	}
+	return apps
+}
+
+// ClusterTrustBundleSelectorRefs returns the currently tracked
+// ClusterTrustBundleKind RefKeys that select bundles by SignerName/
+// LabelSelector rather than by literal Name (see
+// NewClusterTrustBundleSelectorKey). Callers use this to test an
+// incoming bundle against every tracked selector, since a selector RefKey
+// carries no single bundle name to look up directly.
+func (t *AppRefTracker) ClusterTrustBundleSelectorRefs() []RefKey {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var refKeys []RefKey
+	for refKey := range t.refToApps {
+		if refKey.Kind == ClusterTrustBundleKind && refKey.Name == "" {
+			refKeys = append(refKeys, refKey)
+		}
+	}
+	return refKeys
+}
+
+func (t *AppRefTracker) removeAppFromRefLocked(refKey RefKey, appKey AppKey) {
+	delete(t.refToApps[refKey], appKey)
+	if len(t.refToApps[refKey]) == 0 {
+		delete(t.refToApps, refKey)
+	}
+}
+
+// AppUpdateStatus tracks which Apps have a pending forced update due to
+// a change in one of their referenced resources.
+type AppUpdateStatus struct {
+	lock    sync.Mutex
+	pending map[AppKey]struct{}
+}
+
+// NewAppUpdateStatus constructs an empty AppUpdateStatus.
+func NewAppUpdateStatus() *AppUpdateStatus {
+	return &AppUpdateStatus{pending: map[AppKey]struct{}{}}
+}
+
+// MarkForUpdate records that appKey needs to be force-updated on its
+// next reconcile, e.g. because a referenced resource changed.
+func (s *AppUpdateStatus) MarkForUpdate(appKey AppKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pending[appKey] = struct{}{}
+}
+
+// IsUpdateNeeded reports whether appKey has a pending forced update.
+func (s *AppUpdateStatus) IsUpdateNeeded(appKey AppKey) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, found := s.pending[appKey]
+	return found
+}
+
+// MarkUpdated clears any pending forced update for appKey.
+func (s *AppUpdateStatus) MarkUpdated(appKey AppKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.pending, appKey)
+}
@@ -0,0 +1,15 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reftracker
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// NewResourceKey builds a RefKey for an arbitrary resource registered
+// via AppsReconciler.RegisterRefSource (e.g. a user CRD referenced from
+// an App's valuesFrom.resourceRef). name is cluster-scoped-agnostic:
+// namespace is left empty for cluster-scoped resources, same as
+// NewClusterTrustBundleKey.
+func NewResourceKey(gvk schema.GroupVersionKind, namespace, name string) RefKey {
+	return RefKey{Kind: ResourceKind, GVK: gvk, Namespace: namespace, Name: name}
+}
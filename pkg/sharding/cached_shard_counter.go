@@ -0,0 +1,76 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheTTL is the default memoization window used by
+// CachedShardCounter when one is not otherwise configured.
+const DefaultCacheTTL = 10 * time.Second
+
+// CachedShardCounter wraps a ShardCounter and memoizes its result for
+// ttl, refreshing lazily on the next call once the cached value has
+// expired. This keeps Reconcile from listing Leases on every single
+// call while still picking up shard membership changes within ttl.
+// Concurrent callers that all observe an expired cache at once collapse
+// into a single call to inner via singleflight, so a stampede of Apps
+// reconciling at the same moment doesn't turn into a Lease-list
+// stampede.
+type CachedShardCounter struct {
+	inner   ShardCounter
+	ttl     time.Duration
+	nowFunc func() time.Time
+
+	lock      sync.Mutex
+	shards    []string
+	expiresAt time.Time
+
+	group singleflight.Group
+}
+
+// NewCachedShardCounter constructs a CachedShardCounter around inner.
+// A ttl of zero falls back to DefaultCacheTTL.
+func NewCachedShardCounter(inner ShardCounter, ttl time.Duration) *CachedShardCounter {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CachedShardCounter{inner: inner, ttl: ttl, nowFunc: time.Now}
+}
+
+// Shards returns the cached shard list, refreshing it from inner if the
+// cache has expired.
+func (c *CachedShardCounter) Shards(ctx context.Context) ([]string, error) {
+	c.lock.Lock()
+	if c.nowFunc().Before(c.expiresAt) {
+		shards := c.shards
+		c.lock.Unlock()
+		return shards, nil
+	}
+	c.lock.Unlock()
+
+	result, err, _ := c.group.Do("shards", func() (interface{}, error) {
+		shards, err := c.inner.Shards(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.lock.Lock()
+		c.shards = shards
+		c.expiresAt = c.nowFunc().Add(c.ttl)
+		c.lock.Unlock()
+
+		return shards, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]string), nil
+}
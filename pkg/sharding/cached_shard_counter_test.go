@@ -0,0 +1,139 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeShardCounter struct {
+	shards [][]string
+	calls  int
+}
+
+func (f *fakeShardCounter) Shards(_ context.Context) ([]string, error) {
+	i := f.calls
+	if i > len(f.shards)-1 {
+		i = len(f.shards) - 1
+	}
+	f.calls++
+	return f.shards[i], nil
+}
+
+func TestCachedShardCounterMemoizesWithinTTL(t *testing.T) {
+	fake := &fakeShardCounter{shards: [][]string{{"shard-a"}, {"shard-a", "shard-b"}}}
+	cache := NewCachedShardCounter(fake, 10*time.Second)
+
+	now := time.Now()
+	cache.nowFunc = func() time.Time { return now }
+
+	shards, err := cache.Shards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(shards) != 1 {
+		t.Fatalf("expected initial cached value, got %v", shards)
+	}
+
+	// Still within TTL: should not hit the underlying counter again.
+	now = now.Add(5 * time.Second)
+	shards, err = cache.Shards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(shards) != 1 {
+		t.Fatalf("expected stale-but-fresh cached value, got %v", shards)
+	}
+	if fake.calls != 1 {
+		t.Fatalf("expected no refresh within TTL (1 underlying call), got %d calls", fake.calls)
+	}
+}
+
+func TestCachedShardCounterRefreshesAfterTTLExpires(t *testing.T) {
+	fake := &fakeShardCounter{shards: [][]string{{"shard-a"}, {"shard-a", "shard-b"}}}
+	cache := NewCachedShardCounter(fake, 10*time.Second)
+
+	now := time.Now()
+	cache.nowFunc = func() time.Time { return now }
+
+	_, err := cache.Shards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	now = now.Add(11 * time.Second)
+	shards, err := cache.Shards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(shards) != 2 {
+		t.Fatalf("expected refreshed value with 2 shards, got %v", shards)
+	}
+}
+
+// blockingShardCounter counts how many calls actually reach Shards
+// concurrently, so a singleflight regression (every caller hitting
+// inner) shows up as concurrentCalls > 1.
+type blockingShardCounter struct {
+	release chan struct{}
+
+	lock            sync.Mutex
+	concurrentCalls int
+	maxConcurrent   int
+}
+
+func (b *blockingShardCounter) Shards(_ context.Context) ([]string, error) {
+	b.lock.Lock()
+	b.concurrentCalls++
+	if b.concurrentCalls > b.maxConcurrent {
+		b.maxConcurrent = b.concurrentCalls
+	}
+	b.lock.Unlock()
+
+	<-b.release
+
+	b.lock.Lock()
+	b.concurrentCalls--
+	b.lock.Unlock()
+
+	return []string{"shard-a"}, nil
+}
+
+func TestCachedShardCounterDedupesConcurrentRefreshes(t *testing.T) {
+	fake := &blockingShardCounter{release: make(chan struct{})}
+	cache := NewCachedShardCounter(fake, 10*time.Second)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := cache.Shards(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+
+	// Give every goroutine a chance to observe the expired cache and
+	// call in before letting the single underlying call proceed.
+	time.Sleep(50 * time.Millisecond)
+	close(fake.release)
+	wg.Wait()
+
+	if fake.maxConcurrent > 1 {
+		t.Fatalf("expected concurrent refreshes to collapse into one inner call, got %d concurrent calls", fake.maxConcurrent)
+	}
+}
+
+func TestNewCachedShardCounterDefaultsTTL(t *testing.T) {
+	cache := NewCachedShardCounter(&fakeShardCounter{shards: [][]string{{"shard-a"}}}, 0)
+	if cache.ttl != DefaultCacheTTL {
+		t.Fatalf("expected default TTL %s, got %s", DefaultCacheTTL, cache.ttl)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config holds the flags needed to enable lease-based sharding. The
+// zero value has Enabled == false, so existing single-replica installs
+// are unaffected unless --sharding-enabled is set.
+type Config struct {
+	Enabled               bool
+	ShardIdentity         string
+	LeaseNamespace        string
+	Freshness             time.Duration
+	CacheTTL              time.Duration
+	StaticShardIdentities []string
+}
+
+// RegisterFlags binds Config's fields to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.Enabled, "sharding-enabled", false,
+		"Enable lease-based sharding of App reconciliation across kapp-controller replicas")
+	fs.StringVar(&c.ShardIdentity, "sharding-shard-identity", "",
+		"Stable identity this replica publishes its Lease under (required when sharding is enabled)")
+	fs.StringVar(&c.LeaseNamespace, "sharding-lease-namespace", "kapp-controller",
+		"Namespace in which shard Leases are published and listed")
+	fs.DurationVar(&c.Freshness, "sharding-lease-freshness", 15*time.Second,
+		"How recently a shard's Lease must have renewed for that shard to be considered live")
+	fs.DurationVar(&c.CacheTTL, "sharding-cache-ttl", DefaultCacheTTL,
+		"How long a resolved shard list is cached before being refreshed")
+	fs.Var(newStaticShardIdentitiesValue(&c.StaticShardIdentities), "sharding-static-shard-identities",
+		"Comma-separated fallback shard identities to assume before any Leases exist yet, e.g. during initial "+
+			"bootstrap. Must match the --sharding-shard-identity each replica is started with, or every replica "+
+			"will resolve a winner that is never itself")
+}
+
+// staticShardIdentitiesValue adapts a comma-separated flag string onto a
+// []string, the same way stdlib flags like FlagSet.Var are typically
+// extended for list-valued flags.
+type staticShardIdentitiesValue struct {
+	identities *[]string
+}
+
+func newStaticShardIdentitiesValue(identities *[]string) *staticShardIdentitiesValue {
+	return &staticShardIdentitiesValue{identities: identities}
+}
+
+func (v *staticShardIdentitiesValue) String() string {
+	if v.identities == nil {
+		return ""
+	}
+	return strings.Join(*v.identities, ",")
+}
+
+func (v *staticShardIdentitiesValue) Set(s string) error {
+	if s == "" {
+		*v.identities = nil
+		return nil
+	}
+	*v.identities = strings.Split(s, ",")
+	return nil
+}
+
+// Validate checks that Config is internally consistent, e.g. that a
+// ShardIdentity was provided when sharding is enabled.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ShardIdentity == "" {
+		return fmt.Errorf("sharding-shard-identity must be set when sharding-enabled is true")
+	}
+	return nil
+}
+
+// StaticShards returns the fallback shard identities to assume before
+// any Leases exist, for use by RendezvousResolver during bootstrap.
+// These must be the actual --sharding-shard-identity values the
+// replicas are started with: a fabricated identity (e.g. "shard-0")
+// could never equal any replica's real shardIdentity, so
+// RendezvousResolver.IsManagedByShard would resolve a winner that is
+// never itself and no replica would reconcile anything.
+func (c *Config) StaticShards() []string {
+	return c.StaticShardIdentities
+}
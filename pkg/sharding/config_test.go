@@ -0,0 +1,59 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestConfigValidateRequiresShardIdentityWhenEnabled(t *testing.T) {
+	cfg := Config{Enabled: true}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error when sharding is enabled without a shard identity")
+	}
+
+	cfg.ShardIdentity = "replica-0"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestConfigValidateSkipsChecksWhenDisabled(t *testing.T) {
+	cfg := Config{}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error for disabled config: %s", err)
+	}
+}
+
+func TestConfigStaticShards(t *testing.T) {
+	cfg := Config{StaticShardIdentities: []string{"replica-0", "replica-1", "replica-2"}}
+	shards := cfg.StaticShards()
+	if len(shards) != 3 {
+		t.Fatalf("expected 3 static shards, got %v", shards)
+	}
+	if shards[0] != "replica-0" || shards[1] != "replica-1" {
+		t.Fatalf("expected static shards to be the configured identities verbatim, got %v", shards)
+	}
+}
+
+func TestConfigRegisterFlagsParsesStaticShardIdentities(t *testing.T) {
+	var cfg Config
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+
+	if err := fs.Parse([]string{"-sharding-static-shard-identities", "replica-0,replica-1"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []string{"replica-0", "replica-1"}
+	if len(cfg.StaticShardIdentities) != len(want) {
+		t.Fatalf("expected %v, got %v", want, cfg.StaticShardIdentities)
+	}
+	for i := range want {
+		if cfg.StaticShardIdentities[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, cfg.StaticShardIdentities)
+		}
+	}
+}
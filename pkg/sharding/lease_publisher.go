@@ -0,0 +1,112 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// DefaultRenewEvery is how often LeasePublisher renews its Lease when
+// one is not otherwise configured. It should be well under the
+// freshness window that ShardCounter implementations use to decide a
+// shard has gone stale.
+const DefaultRenewEvery = 5 * time.Second
+
+// LeasePublisher periodically creates/renews a coordination.k8s.io
+// Lease labeled with ShardLabel under this replica's stable identity,
+// so that other replicas' LeaseShardCounter can discover it. Without a
+// LeasePublisher running on every replica, no shard ever appears live
+// and sharding never actually activates.
+type LeasePublisher struct {
+	log        logr.Logger
+	client     kubernetes.Interface
+	namespace  string
+	identity   string
+	renewEvery time.Duration
+	nowFunc    func() time.Time
+}
+
+var _ manager.Runnable = &LeasePublisher{}
+
+// NewLeasePublisher constructs a LeasePublisher for the given shard
+// identity. renewEvery of zero falls back to DefaultRenewEvery.
+func NewLeasePublisher(log logr.Logger, client kubernetes.Interface, namespace, identity string, renewEvery time.Duration) *LeasePublisher {
+	if renewEvery <= 0 {
+		renewEvery = DefaultRenewEvery
+	}
+	return &LeasePublisher{log: log, client: client, namespace: namespace, identity: identity, renewEvery: renewEvery, nowFunc: time.Now}
+}
+
+// Start implements manager.Runnable: it publishes the Lease immediately
+// and then renews it every renewEvery until ctx is done. A failure to
+// renew is logged and retried on the next tick rather than returned,
+// since returning an error here would make controller-runtime tear down
+// the entire manager over what's usually a transient API server blip --
+// only the initial publish, without which this shard would never appear
+// live at all, is treated as fatal. Callers should register it with the
+// manager, e.g. mgr.Add(leasePublisher).
+func (p *LeasePublisher) Start(ctx context.Context) error {
+	if err := p.publish(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(p.renewEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.publish(ctx); err != nil {
+				p.log.Error(err, "Could not renew shard Lease", "identity", p.identity)
+			}
+		}
+	}
+}
+
+func (p *LeasePublisher) publish(ctx context.Context) error {
+	renewTime := metav1.NewMicroTime(p.nowFunc())
+	identity := p.identity
+
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.identity,
+			Namespace: p.namespace,
+			Labels:    map[string]string{ShardLabel: "true"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: &identity,
+			RenewTime:      &renewTime,
+		},
+	}
+
+	_, err := p.client.CoordinationV1().Leases(p.namespace).Create(ctx, lease, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, err := p.client.CoordinationV1().Leases(p.namespace).Get(ctx, p.identity, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	existing.Labels = lease.Labels
+	existing.Spec.HolderIdentity = &identity
+	existing.Spec.RenewTime = &renewTime
+
+	_, err = p.client.CoordinationV1().Leases(p.namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
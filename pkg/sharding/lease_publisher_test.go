@@ -0,0 +1,89 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLeasePublisherCreatesLabeledLease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+
+	publisher := NewLeasePublisher(logr.Discard(), client, "kapp-controller", "replica-0", time.Second)
+	publisher.nowFunc = func() time.Time { return now }
+
+	if err := publisher.publish(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lease, err := client.CoordinationV1().Leases("kapp-controller").Get(context.Background(), "replica-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected lease to have been created: %s", err)
+	}
+	if lease.Labels[ShardLabel] != "true" {
+		t.Fatalf("expected lease to carry %s=true label, got %v", ShardLabel, lease.Labels)
+	}
+	if lease.Spec.RenewTime == nil || !lease.Spec.RenewTime.Time.Equal(now) {
+		t.Fatalf("expected lease RenewTime to be set to %s, got %v", now, lease.Spec.RenewTime)
+	}
+}
+
+func TestLeasePublisherRenewsExistingLease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	publisher := NewLeasePublisher(logr.Discard(), client, "kapp-controller", "replica-0", time.Second)
+
+	first := time.Now()
+	publisher.nowFunc = func() time.Time { return first }
+	if err := publisher.publish(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second := first.Add(time.Minute)
+	publisher.nowFunc = func() time.Time { return second }
+	if err := publisher.publish(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lease, err := client.CoordinationV1().Leases("kapp-controller").Get(context.Background(), "replica-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lease.Spec.RenewTime == nil || !lease.Spec.RenewTime.Time.Equal(second) {
+		t.Fatalf("expected lease to be renewed to %s, got %v", second, lease.Spec.RenewTime)
+	}
+}
+
+// TestLeasePublisherSurvivesTransientRenewalFailure asserts that Start
+// does not return once the initial publish has succeeded: a later
+// renewal failure (an API server blip, here simulated by a reactor that
+// always errors on Update) must not tear down the whole manager.
+func TestLeasePublisherSurvivesTransientRenewalFailure(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	publisher := NewLeasePublisher(logr.Discard(), client, "kapp-controller", "replica-0", 5*time.Millisecond)
+
+	// Start's own leading publish creates the Lease (it doesn't exist
+	// yet, so it takes the Create path, not Update); only renewals in
+	// the ticker loop that follow will hit this reactor and fail.
+	client.PrependReactor("update", "leases", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("transient api server error")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := publisher.Start(ctx); err != nil {
+		t.Fatalf("expected Start to tolerate renewal failures and return nil on ctx.Done, got: %s", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+)
+
+// ShardResolver maps a namespaced resource to the shard responsible
+// for reconciling it.
+type ShardResolver interface {
+	// IsManagedByShard reports whether the resource identified by
+	// (namespace, name) belongs to the given shard identity.
+	IsManagedByShard(ctx context.Context, namespace, name, shardIdentity string) (bool, error)
+}
+
+// RendezvousResolver resolves shard ownership using highest-random-weight
+// (rendezvous) hashing over the shards reported by counter: each live
+// shard is scored for a given resource key and the highest-scoring shard
+// owns it. Unlike naive `hash % N`, adding or removing a shard only
+// reassigns the resources that hashed highest for the changed shard,
+// roughly O(1/N) of all resources, instead of reshuffling everything.
+type RendezvousResolver struct {
+	counter      ShardCounter
+	staticShards []string
+}
+
+// NewRendezvousResolver constructs a RendezvousResolver backed by
+// counter. staticShards is used as a fallback set of shard identities
+// before any Leases exist yet (e.g. during initial bootstrap of a
+// freshly installed kapp-controller), and is ignored once counter
+// reports at least one live shard.
+func NewRendezvousResolver(counter ShardCounter, staticShards []string) *RendezvousResolver {
+	return &RendezvousResolver{counter: counter, staticShards: staticShards}
+}
+
+// IsManagedByShard reports whether shardIdentity is the highest-weight
+// shard for the App identified by (namespace, name).
+func (r *RendezvousResolver) IsManagedByShard(ctx context.Context, namespace, name, shardIdentity string) (bool, error) {
+	shards, err := r.counter.Shards(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(shards) == 0 {
+		shards = r.staticShards
+	}
+	if len(shards) == 0 {
+		// No shard information at all: every replica owns everything.
+		return true, nil
+	}
+
+	owner := owningShard(shards, namespace, name)
+	return owner == shardIdentity, nil
+}
+
+// owningShard returns the shard with the highest rendezvous weight for
+// the resource key "namespace/name".
+func owningShard(shards []string, namespace, name string) string {
+	key := namespace + "/" + name
+
+	var owner string
+	var ownerWeight uint64
+	for _, shard := range shards {
+		weight := weigh(shard, key)
+		if owner == "" || weight > ownerWeight {
+			owner = shard
+			ownerWeight = weight
+		}
+	}
+
+	return owner
+}
+
+func weigh(shard, key string) uint64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s/%s", shard, key)
+	return h.Sum64()
+}
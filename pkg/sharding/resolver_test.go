@@ -0,0 +1,82 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type staticShardCounter struct {
+	shards []string
+}
+
+func (s staticShardCounter) Shards(_ context.Context) ([]string, error) {
+	return s.shards, nil
+}
+
+func TestRendezvousResolverSingleOwner(t *testing.T) {
+	shards := []string{"shard-0", "shard-1", "shard-2"}
+	resolver := NewRendezvousResolver(staticShardCounter{shards: shards}, nil)
+
+	owners := 0
+	for _, shard := range shards {
+		managed, err := resolver.IsManagedByShard(context.Background(), "ns", "app-1", shard)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if managed {
+			owners++
+		}
+	}
+
+	if owners != 1 {
+		t.Fatalf("expected exactly one owning shard, got %d", owners)
+	}
+}
+
+func TestRendezvousResolverFallsBackToStaticShards(t *testing.T) {
+	resolver := NewRendezvousResolver(staticShardCounter{}, []string{"shard-a", "shard-b"})
+
+	owner := owningShard([]string{"shard-a", "shard-b"}, "ns", "app-1")
+	managed, err := resolver.IsManagedByShard(context.Background(), "ns", "app-1", owner)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !managed {
+		t.Fatalf("expected static fallback shard %q to own the App", owner)
+	}
+}
+
+func TestRendezvousResolverRebalancesOnlyFractionOfKeysOnShardAdd(t *testing.T) {
+	before := []string{"shard-0", "shard-1", "shard-2"}
+	after := append(append([]string{}, before...), "shard-3")
+
+	const totalApps = 2000
+	moved := 0
+	for i := 0; i < totalApps; i++ {
+		name := appName(i)
+		ownerBefore := owningShard(before, "ns", name)
+		ownerAfter := owningShard(after, "ns", name)
+		if ownerBefore != ownerAfter {
+			moved++
+		}
+	}
+
+	// Adding a 4th shard to 3 should reassign roughly 1/4 of keys;
+	// assert a generous bound so the test isn't flaky while still
+	// catching a resolver that reshuffles everything (e.g. hash % N).
+	maxExpectedMoved := totalApps / 2
+	if moved > maxExpectedMoved {
+		t.Fatalf("expected at most %d/%d keys to move, got %d", maxExpectedMoved, totalApps, moved)
+	}
+	if moved == 0 {
+		t.Fatalf("expected some keys to move to the new shard, got 0")
+	}
+}
+
+func appName(i int) string {
+	return fmt.Sprintf("app-%d", i)
+}
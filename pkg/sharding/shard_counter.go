@@ -0,0 +1,83 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sharding lets multiple kapp-controller replicas horizontally
+// divide ownership of Apps/PackageInstalls instead of relying on a
+// single leader doing all the work. Each replica publishes a
+// coordination.k8s.io Lease announcing its identity; ShardCounter
+// reports how many replicas are currently alive (by lease freshness)
+// and ShardResolver maps a resource to the shard responsible for it,
+// modeled on the sharding scheme used by apiserver-network-proxy.
+package sharding
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ShardLabel marks the Leases that identify a live kapp-controller
+// shard. Replicas that are not participating in sharding (e.g. a
+// single-replica install) do not publish this lease and every App is
+// handled locally.
+const ShardLabel = "kapp-controller.carvel.dev/shard"
+
+// ShardCounter reports the set of shard identities that are currently
+// alive, as observed via their Lease renewTime.
+type ShardCounter interface {
+	// Shards returns the sorted, stable identities of all live shards.
+	Shards(ctx context.Context) ([]string, error)
+}
+
+// LeaseShardCounter implements ShardCounter by listing Leases labeled
+// with ShardLabel in a given namespace and keeping only the ones whose
+// renewTime is within freshness of now.
+type LeaseShardCounter struct {
+	client    kubernetes.Interface
+	namespace string
+	freshness time.Duration
+	nowFunc   func() time.Time
+}
+
+// NewLeaseShardCounter constructs a LeaseShardCounter. freshness is how
+// recently a Lease must have renewed to be considered live; a
+// replica that has crashed will stop renewing and fall out of the
+// count once its lease goes stale.
+func NewLeaseShardCounter(client kubernetes.Interface, namespace string, freshness time.Duration) *LeaseShardCounter {
+	return &LeaseShardCounter{client: client, namespace: namespace, freshness: freshness, nowFunc: time.Now}
+}
+
+// Shards lists Leases labeled with ShardLabel and returns the names of
+// the ones that have renewed within the freshness window, sorted for
+// stable rendezvous hashing.
+func (c *LeaseShardCounter) Shards(ctx context.Context) ([]string, error) {
+	leases, err := c.client.CoordinationV1().Leases(c.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: ShardLabel + "=true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.nowFunc()
+	var shards []string
+	for _, lease := range leases.Items {
+		if isFresh(lease, now, c.freshness) {
+			shards = append(shards, lease.Name)
+		}
+	}
+
+	sort.Strings(shards)
+
+	return shards, nil
+}
+
+func isFresh(lease coordinationv1.Lease, now time.Time, freshness time.Duration) bool {
+	if lease.Spec.RenewTime == nil {
+		return false
+	}
+	return now.Sub(lease.Spec.RenewTime.Time) <= freshness
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func lease(name string, renewedAgo time.Duration, now time.Time) *coordinationv1.Lease {
+	renewTime := metav1.NewMicroTime(now.Add(-renewedAgo))
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kapp-controller",
+			Labels:    map[string]string{ShardLabel: "true"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			RenewTime: &renewTime,
+		},
+	}
+}
+
+func TestLeaseShardCounterExcludesStaleLeases(t *testing.T) {
+	now := time.Now()
+
+	client := fake.NewSimpleClientset(
+		lease("shard-fresh", time.Second, now),
+		lease("shard-stale", time.Minute, now),
+	)
+
+	counter := NewLeaseShardCounter(client, "kapp-controller", 10*time.Second)
+	counter.nowFunc = func() time.Time { return now }
+
+	shards, err := counter.Shards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(shards) != 1 || shards[0] != "shard-fresh" {
+		t.Fatalf("expected only shard-fresh to be live, got %v", shards)
+	}
+}
+
+func TestLeaseShardCounterIgnoresLeasesWithoutRenewTime(t *testing.T) {
+	now := time.Now()
+
+	withoutRenewTime := lease("shard-no-renew", 0, now)
+	withoutRenewTime.Spec.RenewTime = nil
+
+	client := fake.NewSimpleClientset(withoutRenewTime)
+
+	counter := NewLeaseShardCounter(client, "kapp-controller", 10*time.Second)
+	counter.nowFunc = func() time.Time { return now }
+
+	shards, err := counter.Shards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(shards) != 0 {
+		t.Fatalf("expected no live shards, got %v", shards)
+	}
+}